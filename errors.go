@@ -18,7 +18,10 @@
 // error attributes.
 //
 // Errors can be wrapped. Simply add an error to the call of E(). It can then be
-// obtained via the Error.Unwrap() method or the Err field of the Error.
+// obtained via the Error.Unwrap() method or the Err field of the Error. More than
+// one error can be wrapped this way; all of them are returned by Unwrap() and
+// stored in the Errs field, while Err keeps holding the first one. Use Join() to
+// combine several errors into one Error without any other attributes.
 //
 // Best Pratices:
 //
@@ -51,12 +54,18 @@
 //   // ...
 //   return errors.E(EIllegalConfigValue, ...)
 //
+// Kind and Code are sentinel errors: errors.Is(err, errors.NotFound) or
+// errors.Is(err, EIllegalConfigValue) report whether err or any error it wraps
+// carries that Kind or Code. GetKind() and GetCode() return the value itself
+// instead of just a bool.
+//
 // There are usage examples in the documentation of the most important functions of this package.
 // They can also be found in the examples_test.go file.
 package errors
 
 import (
 	"fmt"
+	"runtime"
 	"strings"
 )
 
@@ -64,26 +73,32 @@ import (
 // All error information included in the call to E() will be added to
 // the respective fields of this struct
 type Error struct {
-	Module    Mod    // The module/package in which the error occured
-	Function  Func   // The function in which the error occurred
-	Kind      Kind   // The kind of error
-	Operation Op     // The operation (in the Function) that caused the error
-	Object    Obj    // The object on which operations were performed
-	Code      Code   // An error code identifying the error
-	Details   string // Further details that were passed into E()
-	Err       error  // Wrapped error: the cause of this error
+	Module    Mod             // The module/package in which the error occured
+	Function  Func            // The function in which the error occurred
+	Kind      Kind            // The kind of error
+	Operation Op              // The operation (in the Function) that caused the error
+	Object    Obj             // The object on which operations were performed
+	Code      Code            // An error code identifying the error
+	Details   string          // Further details that were passed into E()
+	Severity  Severity        // How severe this error is
+	Err       error           // Wrapped error: the first error this Error wraps, kept for backwards compatibility. Same as Errs[0]
+	Errs      []error         // All errors wrapped by this Error
+	Frames    []runtime.Frame // The stack frames captured when this Error was created
 }
 
 // E create a new error from a list of provided error attributes. Attributes of types
-// Mod, Func, Obj, Op, Kind, and Code are stored in the respective fields
+// Mod, Func, Obj, Op, Kind, Code, and Severity are stored in the respective fields
 // of the returned Error. If an attribute type appears more then once the latter
 // one takes precedence.
-// If an attribute implementing the error interface the new Error
-// wraps the provided error.
+// If one or more attributes implement the error interface, the new Error wraps all
+// of them: Errs holds all wrapped errors in the order they were passed in and Err
+// holds the first of them, for backwards compatibility.
 // String representations of all attributes of other types are added to the Details field.
 // These details are separated by semicolons.
 func E(args ...interface{}) Error {
-	return e(Error{}, args...)
+	err := e(Error{}, args...)
+	err.Frames = captureStack()
+	return err
 }
 
 // Template is a template for Error creation. It stores
@@ -116,7 +131,9 @@ func (tpl Template) T(args ...interface{}) Template {
 //
 // See errors.E() for details on the attribute list.
 func (tpl Template) E(args ...interface{}) Error {
-	return e(Error(tpl), args...)
+	err := e(Error(tpl), args...)
+	err.Frames = captureStack()
+	return err
 }
 
 // Kind is an error attribute that describes the kind of error. E.g. write error, read error
@@ -186,71 +203,126 @@ func e(err Error, args ...interface{}) Error {
 			err.Operation = x
 		case Obj:
 			err.Object = x
+		case Severity:
+			err.Severity = x
 		case Code:
 			err.Code = x
 		case string:
 			err.Details = err.Details + "; " + x
 		case error:
-			err.Err = x
+			err.Errs = append(err.Errs, x)
 		default:
 			err.Details = err.Details + fmt.Sprintf("%v; ", x)
 		}
 	}
+	if len(err.Errs) > 0 {
+		err.Err = err.Errs[0]
+	}
 	err.Details = strings.Trim(err.Details, "; ")
 	return err
 }
 
-// Error returns a string representation fo this Error.
-func (err Error) Error() (ret string) {
-	defer func() {
-		ret = strings.Trim(ret, "\n- ;/")
-	}()
-	var e error = err
-	for e != nil {
-		if ret != "" {
-			ret = ret + "\n"
-		}
-		ret = ret + " - "
-		err2, ok := e.(Error)
-		if !ok {
-			ret = ret + e.Error()
-			return
-		}
-		s := string(err2.Module)
-		if err2.Function != "" {
-			s = s + "/" + string(err2.Function)
-		}
-		if err2.Object != "" {
-			s = s + " [" + string(err2.Object) + "]"
-		}
-		s = s + "\n   "
-		if err2.Operation != "" {
-			s = s + string(err2.Operation) + ": "
-		}
-		if err.Kind != "" {
-			s = s + string(err2.Kind)
-			if err.Code == "" {
-				s = s + ":"
-			}
-			s = s + " "
+// Join combines multiple errors into one Error. The returned Error wraps all
+// of the non-nil errors in errs: Errs holds all of them in order and Err
+// holds the first one, for backwards compatibility. Nil errors are ignored,
+// mirroring the standard library's errors.Join.
+func Join(errs ...error) Error {
+	var joined Error
+	for _, err := range errs {
+		if err == nil {
+			continue
 		}
-		if err2.Code != "" {
-			s = s + "(" + string(err2.Code) + "): "
+		joined.Errs = append(joined.Errs, err)
+	}
+	if len(joined.Errs) > 0 {
+		joined.Err = joined.Errs[0]
+	}
+	joined.Frames = captureStack()
+	return joined
+}
+
+// Error returns a string representation fo this Error, rendered with the
+// package's default formatter (see SetDefaultFormatter). If this Error wraps
+// more than one error (see Errs), each of them is rendered in its own "- "
+// block.
+func (err Error) Error() string {
+	return err.FormatWith(defaultFormatter)
+}
+
+// header renders this Error's own attributes (without its wrapped errors) the
+// same way Error() always has: module/function, object, severity, operation,
+// kind, code and details.
+func (err Error) header() string {
+	s := string(err.Module)
+	if err.Function != "" {
+		s = s + "/" + string(err.Function)
+	}
+	if err.Object != "" {
+		s = s + " [" + string(err.Object) + "]"
+	}
+	if err.Severity != 0 {
+		s = s + " <" + err.Severity.String() + ">"
+	}
+	s = s + "\n   "
+	if err.Operation != "" {
+		s = s + string(err.Operation) + ": "
+	}
+	if err.Kind != "" {
+		s = s + string(err.Kind)
+		if err.Code == "" {
+			s = s + ":"
 		}
-		if err2.Details != "" {
-			s = s + err2.Details
+		s = s + " "
+	}
+	if err.Code != "" {
+		s = s + "(" + string(err.Code) + "): "
+	}
+	if err.Details != "" {
+		s = s + err.Details
+	}
+	return strings.Trim(s, " /\n:;")
+}
+
+// render renders this Error's header followed by every wrapped error (see
+// Errs), each in its own "- " block. Errors wrapped by an Error are rendered
+// recursively via their own render(); plain errors are rendered via Error().
+func (err Error) render() string {
+	segments := make([]string, 0, 1+len(err.Errs))
+	if h := err.header(); h != "" {
+		segments = append(segments, h)
+	}
+	for _, cause := range err.Errs {
+		rendered := renderCause(cause)
+		if len(err.Errs) > 1 {
+			rendered = indentContinuation(rendered)
 		}
-		s = strings.Trim(s, " /\n:;")
-		ret = ret + s
-		e = err2.Err
+		segments = append(segments, rendered)
+	}
+	return strings.Join(segments, "\n - ")
+}
+
+func renderCause(err error) string {
+	if e, ok := err.(Error); ok {
+		return e.render()
+	}
+	return err.Error()
+}
+
+// indentContinuation indents every line but the first of s by three spaces,
+// so that a multi-cause block stays visually grouped under its "- " marker.
+func indentContinuation(s string) string {
+	lines := strings.Split(s, "\n")
+	for i := 1; i < len(lines); i++ {
+		lines[i] = "   " + lines[i]
 	}
-	return
+	return strings.Join(lines, "\n")
 }
 
-// Unwrap returns the error this Error is wrapping. If no error is
-// wrapped by this error nil will be returned.
-func (err Error) Unwrap() error {
-	return err.Err
+// Unwrap returns all errors wrapped by this Error (see Errs) so that
+// errors.Is and errors.As can traverse the full tree of causes. It returns
+// nil if this Error wraps nothing.
+func (err Error) Unwrap() []error {
+	return err.Errs
 }
 
 const (
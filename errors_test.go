@@ -1,7 +1,11 @@
 package errors_test
 
 import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/tweithoener/errors"
@@ -51,8 +55,9 @@ func TestError_Unwrap(t *testing.T) {
 		errs[i] = errors.E(i, errs[i-1])
 	}
 	for i := 4; i >= 1; i-- {
-		if u := errs[i].Unwrap(); u != errs[i-1] {
-			t.Errorf("%s.Unwrap() = %s; expected %s", errs[i].Error(), u.Error(), errs[i-1].Error())
+		u := errs[i].Unwrap()
+		if len(u) != 1 || !reflect.DeepEqual(u[0], errs[i-1]) {
+			t.Errorf("%s.Unwrap() = %v; expected [%s]", errs[i].Error(), u, errs[i-1].Error())
 		}
 	}
 }
@@ -63,3 +68,251 @@ func TestEWithNill(t *testing.T) {
 		t.Errorf("E(<with nil>) = %s; expected %s", s, check)
 	}
 }
+
+func TestStackCapture(t *testing.T) {
+	err := errors.E("boom")
+	if len(err.StackTrace()) == 0 {
+		t.Errorf("StackTrace() is empty; expected at least one frame")
+	}
+	if !strings.Contains(fmt.Sprintf("%+v", err), err.StackTrace()[0].Function) {
+		t.Errorf("%%+v output does not contain the captured stack trace")
+	}
+	if strings.Contains(fmt.Sprintf("%v", err), err.StackTrace()[0].Function) {
+		t.Errorf("%%v output should not contain the stack trace")
+	}
+
+	errors.DisableStackCapture(true)
+	defer errors.DisableStackCapture(false)
+	if st := errors.E("boom").StackTrace(); st != nil {
+		t.Errorf("StackTrace() = %v; expected nil while capturing is disabled", st)
+	}
+}
+
+func TestStackCaptureMultiCause(t *testing.T) {
+	e1 := errors.E("err1")
+	e2 := errors.E("err2")
+	joined := errors.E("wrapper", e1, e2)
+
+	out := fmt.Sprintf("%+v", joined)
+	for name, f := range map[string]errors.Error{"joined": joined, "e1": e1, "e2": e2} {
+		// e1, e2 and joined are created on different lines, so the
+		// file:line of each top frame tells their traces apart
+		top := f.StackTrace()[0]
+		if !strings.Contains(out, fmt.Sprintf("%s:%d", top.File, top.Line)) {
+			t.Errorf("%%+v output is missing the stack trace of %s", name)
+		}
+	}
+}
+
+func TestJoin(t *testing.T) {
+	err1 := stderrors.New("err1")
+	err2 := stderrors.New("err2")
+	joined := errors.Join(err1, nil, err2)
+
+	if !reflect.DeepEqual(joined.Errs, []error{err1, err2}) {
+		t.Errorf("Join(...).Errs = %v; expected [%v %v]", joined.Errs, err1, err2)
+	}
+	if joined.Err != err1 {
+		t.Errorf("Join(...).Err = %v; expected %v", joined.Err, err1)
+	}
+	if !stderrors.Is(joined, err1) || !stderrors.Is(joined, err2) {
+		t.Errorf("stderrors.Is() does not find all errors wrapped by Join()")
+	}
+}
+
+func TestIsKindAndCode(t *testing.T) {
+	err := errors.E(errors.NotFound, errors.Code("E404"), "item")
+	if !stderrors.Is(err, errors.NotFound) {
+		t.Errorf("stderrors.Is(err, errors.NotFound) = false; expected true")
+	}
+	if stderrors.Is(err, errors.NotAllowed) {
+		t.Errorf("stderrors.Is(err, errors.NotAllowed) = true; expected false")
+	}
+	if !stderrors.Is(err, errors.Code("E404")) {
+		t.Errorf("stderrors.Is(err, errors.Code(\"E404\")) = false; expected true")
+	}
+
+	wrapped := errors.E("can't load item", err)
+	if !stderrors.Is(wrapped, errors.NotFound) {
+		t.Errorf("stderrors.Is() does not look past the wrapped Error for a matching Kind")
+	}
+
+	matcher := errors.Error{Kind: errors.NotFound, Object: "unused wildcard check"}
+	if !stderrors.Is(wrapped, matcher) {
+		t.Errorf("stderrors.Is() with an Error target should match on Kind alone")
+	}
+}
+
+func TestGetKindAndCode(t *testing.T) {
+	err := errors.E("can't load item", errors.E(errors.NotFound, errors.Code("E404")))
+	if k, ok := errors.GetKind(err); !ok || k != errors.NotFound {
+		t.Errorf("GetKind(err) = %v, %t; expected %v, true", k, ok, errors.NotFound)
+	}
+	if c, ok := errors.GetCode(err); !ok || c != "E404" {
+		t.Errorf("GetCode(err) = %v, %t; expected E404, true", c, ok)
+	}
+	if _, ok := errors.GetKind(errors.E("no kind here")); ok {
+		t.Errorf("GetKind() = true; expected false for an Error without a Kind")
+	}
+}
+
+func TestJSONRoundtrip(t *testing.T) {
+	inner := errors.E(errors.Mod("store"), errors.Kind("not found"), "item missing")
+	outer := errors.E(errors.Mod("api"), errors.Func("Get"), errors.Op("load item"), inner, stderrors.New("plain cause"))
+
+	data, merr := json.Marshal(outer)
+	if merr != nil {
+		t.Fatalf("json.Marshal() failed: %v", merr)
+	}
+
+	var got errors.Error
+	if uerr := json.Unmarshal(data, &got); uerr != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", uerr)
+	}
+
+	if got.Module != outer.Module || got.Function != outer.Function || got.Operation != outer.Operation {
+		t.Errorf("json roundtrip lost attributes: got %+v; expected %+v", got, outer)
+	}
+	if len(got.Errs) != 2 {
+		t.Fatalf("json roundtrip produced %d causes; expected 2", len(got.Errs))
+	}
+	nested, ok := got.Errs[0].(errors.Error)
+	if !ok || nested.Kind != errors.Kind("not found") {
+		t.Errorf("json roundtrip lost the nested Error cause: %+v", got.Errs[0])
+	}
+	if got.Errs[1].Error() != "plain cause" {
+		t.Errorf("json roundtrip of the plain cause = %q; expected %q", got.Errs[1].Error(), "plain cause")
+	}
+}
+
+func TestMarshalText(t *testing.T) {
+	err := errors.E("boom")
+	text, merr := err.MarshalText()
+	if merr != nil {
+		t.Fatalf("MarshalText() failed: %v", merr)
+	}
+	if string(text) != err.Error() {
+		t.Errorf("MarshalText() = %q; expected %q", text, err.Error())
+	}
+}
+
+func TestTemplateFormatter(t *testing.T) {
+	f, nerr := errors.NewTemplateFormatter("{{.Module}}: {{.Details}}{{with .Wrapped}} (caused by: {{.}}){{end}}")
+	if nerr != nil {
+		t.Fatalf("NewTemplateFormatter() failed: %v", nerr)
+	}
+
+	cause := errors.E("disk full")
+	err := errors.E(errors.Mod("store"), "save failed", cause)
+
+	check := "store: save failed (caused by: : disk full)"
+	if s := err.FormatWith(f); s != check {
+		t.Errorf("FormatWith(TemplateFormatter) = %q; expected %q", s, check)
+	}
+}
+
+func TestTemplateFormatterSeverity(t *testing.T) {
+	f, nerr := errors.NewTemplateFormatter("{{.Severity}}: {{.Details}}")
+	if nerr != nil {
+		t.Fatalf("NewTemplateFormatter() failed: %v", nerr)
+	}
+
+	err := errors.E(errors.SevWarn, "disk almost full")
+
+	check := "warn: disk almost full"
+	if s := err.FormatWith(f); s != check {
+		t.Errorf("FormatWith(TemplateFormatter) = %q; expected %q", s, check)
+	}
+
+	check = ": no severity set"
+	if s := errors.E("no severity set").FormatWith(f); s != check {
+		t.Errorf("FormatWith(TemplateFormatter) = %q; expected %q", s, check)
+	}
+}
+
+func TestTemplateFormatterCauses(t *testing.T) {
+	f, nerr := errors.NewTemplateFormatter("{{.Details}}{{range causes .}}\n - {{.}}{{end}}")
+	if nerr != nil {
+		t.Fatalf("NewTemplateFormatter() failed: %v", nerr)
+	}
+
+	err := errors.Join(errors.E("err1"), errors.E("err2"))
+
+	check := "\n - err1\n - err2"
+	if s := err.FormatWith(f); s != check {
+		t.Errorf("FormatWith(TemplateFormatter) = %q; expected %q", s, check)
+	}
+}
+
+func TestSetDefaultFormatter(t *testing.T) {
+	f, nerr := errors.NewTemplateFormatter("custom: {{.Details}}")
+	if nerr != nil {
+		t.Fatalf("NewTemplateFormatter() failed: %v", nerr)
+	}
+	errors.SetDefaultFormatter(f)
+	defer errors.SetDefaultFormatter(nil)
+
+	check := "custom: boom"
+	if s := errors.E("boom").Error(); s != check {
+		t.Errorf("Error() after SetDefaultFormatter() = %q; expected %q", s, check)
+	}
+}
+
+func TestSeverity(t *testing.T) {
+	err := errors.E(errors.SevWarn, "disk almost full")
+	if err.Severity != errors.SevWarn {
+		t.Errorf("E(...).Severity = %v; expected %v", err.Severity, errors.SevWarn)
+	}
+	check := "<warn>\n   disk almost full"
+	if s := err.Error(); s != check {
+		t.Errorf("Error() = %q; expected %q", s, check)
+	}
+}
+
+func TestSeverityZeroValueIsUnset(t *testing.T) {
+	withDebug := errors.E(errors.SevDebug, "msg")
+	withoutSeverity := errors.E("msg")
+	if withDebug.Error() == withoutSeverity.Error() {
+		t.Errorf("Error() does not distinguish an explicit SevDebug from no Severity at all: %q", withDebug.Error())
+	}
+	data, merr := json.Marshal(withDebug)
+	if merr != nil {
+		t.Fatalf("json.Marshal() failed: %v", merr)
+	}
+	if !strings.Contains(string(data), `"severity":"debug"`) {
+		t.Errorf("MarshalJSON() = %s; expected a \"severity\":\"debug\" field", data)
+	}
+}
+
+func TestSeverityf(t *testing.T) {
+	if s := errors.Severityf("%s", "warn"); s != errors.SevWarn {
+		t.Errorf("Severityf(\"warn\") = %v; expected %v", s, errors.SevWarn)
+	}
+	if s := errors.Severityf("%s", "nonsense"); s != errors.SevFatal {
+		t.Errorf("Severityf(\"nonsense\") = %v; expected %v (fail safe, not silently downgraded)", s, errors.SevFatal)
+	}
+}
+
+func TestMaxSeverity(t *testing.T) {
+	cause := errors.E(errors.SevFatal, "disk failed")
+	err := errors.E(errors.SevWarn, "save failed", cause)
+	if s := errors.MaxSeverity(err); s != errors.SevFatal {
+		t.Errorf("MaxSeverity(err) = %v; expected %v", s, errors.SevFatal)
+	}
+	if s := errors.MaxSeverity(stderrors.New("plain")); s != errors.Severity(0) {
+		t.Errorf("MaxSeverity(<plain error>) = %v; expected the zero value", s)
+	}
+}
+
+func TestEMultiError(t *testing.T) {
+	err1 := stderrors.New("err1")
+	err2 := stderrors.New("err2")
+	err := errors.E("multiple failures", err1, err2)
+
+	if !stderrors.Is(err, err1) || !stderrors.Is(err, err2) {
+		t.Errorf("stderrors.Is() does not find all errors passed into E()")
+	}
+	if err.Err != err1 {
+		t.Errorf("E(...).Err = %v; expected %v", err.Err, err1)
+	}
+}
@@ -100,6 +100,42 @@ func ExampleE() {
 	// illegal value: {1 2}
 }
 
+func ExampleJoin() {
+	// collect the errors of a loop that processes several items without
+	// giving up on the remaining items
+	var itemErrs []error
+	for _, item := range []string{"a", "b", "c"} {
+		if item != "a" {
+			itemErrs = append(itemErrs, errors.E(item+" failed"))
+		}
+	}
+
+	// Join combines them into a single error. Nothing is lost: errors.Is and
+	// errors.As still find every one of them via Unwrap().
+	err := errors.Join(itemErrs...)
+	fmt.Println(err.Error())
+
+	// Output:
+	// b failed
+	//  - c failed
+}
+
+func ExampleNewTemplateFormatter() {
+	// build a formatter that renders errors as a single log-friendly line,
+	// using "causes" to flatten the full chain of wrapped errors
+	f, err := errors.NewTemplateFormatter(
+		"{{.Module}}: {{.Details}}{{range causes .}}; caused by: {{.}}{{end}}")
+	if err != nil {
+		panic(err)
+	}
+
+	e := errors.E(errors.Mod("store"), "save failed", errors.E("disk full"))
+	fmt.Println(e.FormatWith(f))
+
+	// Output:
+	// store: save failed; caused by: : disk full
+}
+
 func ExampleTemplate_E() {
 	// create a template
 	etpl := errors.T(errors.Func("Template.T example"))
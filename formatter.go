@@ -0,0 +1,144 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Formatter renders an Error as a string. Assign one with
+// SetDefaultFormatter to change what Error() (and thus %v, %s) produces
+// package-wide, or pass one to Error.FormatWith for a one-off rendering.
+type Formatter interface {
+	Format(Error) string
+}
+
+// defaultFormatter is used by Error() and by FormatWith(nil).
+var defaultFormatter Formatter = DefaultFormatter{}
+
+// SetDefaultFormatter replaces the package-wide default Formatter used by
+// Error() (and thus by %v and %s). Passing nil restores DefaultFormatter.
+func SetDefaultFormatter(f Formatter) {
+	if f == nil {
+		f = DefaultFormatter{}
+	}
+	defaultFormatter = f
+}
+
+// FormatWith renders this Error using the given Formatter instead of the
+// package's default formatter. Passing nil uses the current default
+// formatter, same as Error().
+func (err Error) FormatWith(f Formatter) string {
+	if f == nil {
+		f = defaultFormatter
+	}
+	return f.Format(err)
+}
+
+// DefaultFormatter is the Formatter used by Error() unless
+// SetDefaultFormatter is called. It renders the attribute header
+// ("module/function [object] <severity>\n   operation: kind (code): details") followed
+// by every wrapped error, each in its own " - " block.
+type DefaultFormatter struct{}
+
+// Format implements Formatter.
+func (DefaultFormatter) Format(err Error) string {
+	return strings.Trim(err.render(), "\n- ;/")
+}
+
+// TemplateFormatter is a Formatter backed by a text/template. The template
+// has access to this Error's attribute fields (Module, Function, Kind,
+// Operation, Object, Code, Details, Severity) plus Wrapped, which holds the
+// rendered first wrapped error (see Error.Err) or the empty string if there
+// is none.
+//
+// Two helper functions are available inside the template:
+//   - indent prefixes every line of a string, e.g. {{indent "  " .Wrapped}}
+//   - causes renders every error wrapped by this Error (see Errs) as a list
+//     of strings, e.g. {{range causes .}}{{.}}{{end}}
+type TemplateFormatter struct {
+	tpl *template.Template
+}
+
+// templateData is the value passed to a TemplateFormatter's template.
+type templateData struct {
+	Module    string
+	Function  string
+	Kind      string
+	Operation string
+	Object    string
+	Code      string
+	Details   string
+	Severity  string
+	Wrapped   string
+	errs      []error // the errors this Error wraps, for the "causes" template func
+}
+
+// NewTemplateFormatter parses text as a text/template and returns a
+// TemplateFormatter that renders Errors with it. See TemplateFormatter for
+// the fields and helper functions available inside text.
+func NewTemplateFormatter(text string) (*TemplateFormatter, error) {
+	f := &TemplateFormatter{}
+	tpl, err := template.New("error").Funcs(template.FuncMap{
+		"indent": indentLines,
+		"causes": f.causes,
+	}).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	f.tpl = tpl
+	return f, nil
+}
+
+// Format implements Formatter.
+func (f *TemplateFormatter) Format(err Error) string {
+	data := templateData{
+		Module:    string(err.Module),
+		Function:  string(err.Function),
+		Kind:      string(err.Kind),
+		Operation: string(err.Operation),
+		Object:    string(err.Object),
+		Code:      string(err.Code),
+		Details:   err.Details,
+	}
+	if err.Severity != 0 {
+		data.Severity = err.Severity.String()
+	}
+	data.errs = err.Errs
+	if len(err.Errs) > 0 {
+		data.Wrapped = f.renderCause(err.Errs[0])
+	}
+	var b strings.Builder
+	if terr := f.tpl.Execute(&b, data); terr != nil {
+		return fmt.Sprintf("<error formatting error: %v>", terr)
+	}
+	return b.String()
+}
+
+// causes renders every error wrapped by the Error that produced data with
+// this same template, recursively. It is available inside the template as
+// the "causes" function, e.g. {{range causes .}}{{.}}{{end}}.
+func (f *TemplateFormatter) causes(data templateData) []string {
+	rendered := make([]string, 0, len(data.errs))
+	for _, cause := range data.errs {
+		rendered = append(rendered, f.renderCause(cause))
+	}
+	return rendered
+}
+
+func (f *TemplateFormatter) renderCause(err error) string {
+	if e, ok := err.(Error); ok {
+		return f.Format(e)
+	}
+	return err.Error()
+}
+
+// indentLines prefixes every line of s with prefix. It is available inside a
+// TemplateFormatter's template as the "indent" function.
+func indentLines(prefix, s string) string {
+	lines := strings.Split(s, "\n")
+	for i := range lines {
+		lines[i] = prefix + lines[i]
+	}
+	return strings.Join(lines, "\n")
+}
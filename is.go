@@ -0,0 +1,95 @@
+package errors
+
+import "errors"
+
+// Error implements the error interface for Kind so that a Kind can be used
+// as a target for errors.Is, e.g. errors.Is(err, errors.NotFound).
+func (k Kind) Error() string {
+	return string(k)
+}
+
+// Error implements the error interface for Code so that a Code can be used
+// as a target for errors.Is, e.g. errors.Is(err, errors.Code("E123")).
+func (c Code) Error() string {
+	return string(c)
+}
+
+// Is reports whether this Error matches target, so that it can be used with
+// the standard library's errors.Is.
+//
+// If target is a Kind or a Code, Is reports whether it equals this Error's
+// Kind or Code respectively (an empty target never matches).
+//
+// If target is an Error, Is compares its Kind, Code, Operation, Module, and
+// Function fields against this Error's fields. A field that is empty (its
+// zero value) in target acts as a wildcard and is not compared; all other
+// fields must match for Is to report true.
+func (err Error) Is(target error) bool {
+	switch t := target.(type) {
+	case Kind:
+		return t != "" && t == err.Kind
+	case Code:
+		return t != "" && t == err.Code
+	case Error:
+		if t.Kind != "" && t.Kind != err.Kind {
+			return false
+		}
+		if t.Code != "" && t.Code != err.Code {
+			return false
+		}
+		if t.Operation != "" && t.Operation != err.Operation {
+			return false
+		}
+		if t.Module != "" && t.Module != err.Module {
+			return false
+		}
+		if t.Function != "" && t.Function != err.Function {
+			return false
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// GetKind returns the Kind of the nearest Error in err's chain that has a
+// non-empty Kind. It walks the chain of wrapped errors via errors.As,
+// descending into every error wrapped via Errs until a match is found. The
+// second return value is false if neither err nor anything it wraps is an
+// Error with a non-empty Kind.
+func GetKind(err error) (Kind, bool) {
+	var e Error
+	if !errors.As(err, &e) {
+		return "", false
+	}
+	if e.Kind != "" {
+		return e.Kind, true
+	}
+	for _, cause := range e.Errs {
+		if k, ok := GetKind(cause); ok {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// GetCode returns the Code of the nearest Error in err's chain that has a
+// non-empty Code. It walks the chain of wrapped errors via errors.As,
+// descending into every error wrapped via Errs until a match is found. The
+// second return value is false if neither err nor anything it wraps is an
+// Error with a non-empty Code.
+func GetCode(err error) (Code, bool) {
+	var e Error
+	if !errors.As(err, &e) {
+		return "", false
+	}
+	if e.Code != "" {
+		return e.Code, true
+	}
+	for _, cause := range e.Errs {
+		if c, ok := GetCode(cause); ok {
+			return c, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,125 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// jsonError is the wire format used by Error's MarshalJSON/UnmarshalJSON. It
+// names every attribute field, recursively nests wrapped Errors under
+// Causes, and falls back to Message for a wrapped error that is not itself
+// an Error.
+type jsonError struct {
+	Module    string            `json:"module,omitempty"`
+	Function  string            `json:"function,omitempty"`
+	Kind      string            `json:"kind,omitempty"`
+	Operation string            `json:"operation,omitempty"`
+	Object    string            `json:"object,omitempty"`
+	Code      string            `json:"code,omitempty"`
+	Details   string            `json:"details,omitempty"`
+	Severity  string            `json:"severity,omitempty"`
+	Stack     []string          `json:"stack,omitempty"`
+	Causes    []json.RawMessage `json:"causes,omitempty"`
+	Message   string            `json:"message,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. Every attribute of this Error
+// (Module, Function, Kind, Operation, Object, Code, Details, Severity) is
+// marshaled as a named field, Severity as its string label (e.g. "warn").
+// Every error wrapped by this Error (see Errs) is marshaled recursively if
+// it is itself an Error, or as {"message": err.Error()} otherwise. The
+// captured stack trace, if any, is included as "stack".
+func (err Error) MarshalJSON() ([]byte, error) {
+	j := jsonError{
+		Module:    string(err.Module),
+		Function:  string(err.Function),
+		Kind:      string(err.Kind),
+		Operation: string(err.Operation),
+		Object:    string(err.Object),
+		Code:      string(err.Code),
+		Details:   err.Details,
+	}
+	if err.Severity != 0 {
+		j.Severity = err.Severity.String()
+	}
+	for _, f := range err.Frames {
+		j.Stack = append(j.Stack, fmt.Sprintf("%s:%d %s", f.File, f.Line, f.Function))
+	}
+	for _, cause := range err.Errs {
+		raw, merr := marshalCause(cause)
+		if merr != nil {
+			return nil, merr
+		}
+		j.Causes = append(j.Causes, raw)
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, reconstructing an Error from
+// the format produced by MarshalJSON. The captured stack trace is not
+// restored since runtime.Frame values cannot be recreated from their text
+// representation.
+func (err *Error) UnmarshalJSON(data []byte) error {
+	var j jsonError
+	if uerr := json.Unmarshal(data, &j); uerr != nil {
+		return uerr
+	}
+	e, uerr := j.toError()
+	if uerr != nil {
+		return uerr
+	}
+	*err = e
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler. It produces the same
+// multi-line output as Error(), so this Error can be used directly as a
+// structured logging field that expects text.
+func (err Error) MarshalText() ([]byte, error) {
+	return []byte(err.Error()), nil
+}
+
+func marshalCause(err error) (json.RawMessage, error) {
+	if e, ok := err.(Error); ok {
+		return e.MarshalJSON()
+	}
+	return json.Marshal(jsonError{Message: err.Error()})
+}
+
+func unmarshalCause(raw json.RawMessage) (error, error) {
+	var j jsonError
+	if uerr := json.Unmarshal(raw, &j); uerr != nil {
+		return nil, uerr
+	}
+	if j.Message != "" {
+		return errors.New(j.Message), nil
+	}
+	return j.toError()
+}
+
+func (j jsonError) toError() (Error, error) {
+	e := Error{
+		Module:    Mod(j.Module),
+		Function:  Func(j.Function),
+		Kind:      Kind(j.Kind),
+		Operation: Op(j.Operation),
+		Object:    Obj(j.Object),
+		Code:      Code(j.Code),
+		Details:   j.Details,
+	}
+	if j.Severity != "" {
+		e.Severity = parseSeverity(j.Severity)
+	}
+	for _, raw := range j.Causes {
+		cause, cerr := unmarshalCause(raw)
+		if cerr != nil {
+			return Error{}, cerr
+		}
+		e.Errs = append(e.Errs, cause)
+	}
+	if len(e.Errs) > 0 {
+		e.Err = e.Errs[0]
+	}
+	return e, nil
+}
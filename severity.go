@@ -0,0 +1,101 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity is an error attribute that classifies how severe an error is. It
+// can be passed into E() / T() the same way Kind or Code can.
+type Severity uint8
+
+// The zero value of Severity (note the leading blank identifier below) is
+// deliberately not one of the named levels: it means "no Severity was set",
+// so that an Error explicitly created with SevDebug can still be told apart
+// from one that never mentioned a Severity at all.
+const (
+	_ Severity = iota
+	SevDebug
+	SevInfo
+	SevWarn
+	SevError
+	SevFatal
+)
+
+// String returns the lower case name of this Severity, e.g. "warn". The zero
+// value (no Severity set) renders as "unset". Severities outside the range
+// of the defined constants are rendered as "severity(<value>)".
+func (s Severity) String() string {
+	switch s {
+	case 0:
+		return "unset"
+	case SevDebug:
+		return "debug"
+	case SevInfo:
+		return "info"
+	case SevWarn:
+		return "warn"
+	case SevError:
+		return "error"
+	case SevFatal:
+		return "fatal"
+	default:
+		return fmt.Sprintf("severity(%d)", uint8(s))
+	}
+}
+
+// Severityf is a shortcut that formats a severity label with fmt.Sprintf and
+// resolves it to the matching Severity constant (case-insensitive), mirroring
+// the other Xf helpers that build their value with fmt.Sprintf.
+//
+// Unlike the other Xf helpers, this is a lossy conversion: the formatted
+// label must match one of the five known level names (or "warning", which
+// is read as "warn") or Severityf falls back
+// to SevFatal, since silently under-classifying a severity (e.g. a mistyped
+// "ftal") risks suppressing alerting on a real failure. Prefer passing one of
+// the SevXxx constants directly wherever the level is a compile-time
+// constant; reserve Severityf for labels that only exist as text at runtime
+// (e.g. parsed from a config file).
+func Severityf(f string, args ...interface{}) Severity {
+	return parseSeverity(fmt.Sprintf(f, args...))
+}
+
+func parseSeverity(s string) Severity {
+	switch strings.ToLower(s) {
+	case "debug":
+		return SevDebug
+	case "info":
+		return SevInfo
+	case "warn", "warning":
+		return SevWarn
+	case "error":
+		return SevError
+	case "fatal":
+		return SevFatal
+	default:
+		return SevFatal
+	}
+}
+
+// MaxSeverity walks err and, if it is an Error, everything it wraps (see
+// Error.Errs), and returns the highest Severity found. It returns the zero
+// value (no Severity set) if nothing in the chain is an Error with a
+// Severity set. The walk only descends through Error values: an Error
+// wrapped inside a cause that is not itself an Error (e.g. one wrapped with
+// fmt.Errorf and %w) is not examined.
+func MaxSeverity(err error) Severity {
+	var max Severity
+	e, ok := err.(Error)
+	if !ok {
+		return max
+	}
+	if e.Severity > max {
+		max = e.Severity
+	}
+	for _, cause := range e.Errs {
+		if s := MaxSeverity(cause); s > max {
+			max = s
+		}
+	}
+	return max
+}
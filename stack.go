@@ -0,0 +1,108 @@
+package errors
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// pkgFuncPrefix identifies frames that belong to this package so that they
+// can be skipped when a stack trace is captured.
+const pkgFuncPrefix = "github.com/tweithoener/errors."
+
+// stackCaptureDepth is the maximum number of stack frames captured for a
+// new Error. It can be changed with SetStackCaptureDepth.
+var stackCaptureDepth = 32
+
+// stackCaptureDisabled turns stack capturing off globally when true.
+var stackCaptureDisabled = false
+
+// SetStackCaptureDepth sets the maximum number of stack frames that are
+// captured when a new Error is created with E() or Template.E(). The
+// default depth is 32.
+func SetStackCaptureDepth(depth int) {
+	stackCaptureDepth = depth
+}
+
+// DisableStackCapture turns stack trace capturing on or off globally. Use
+// this in performance sensitive code to avoid the cost of walking the
+// call stack for every error that is created.
+func DisableStackCapture(disable bool) {
+	stackCaptureDisabled = disable
+}
+
+// captureStack walks the call stack of the caller and returns the resulting
+// frames, skipping frames that belong to this package itself.
+func captureStack() []runtime.Frame {
+	if stackCaptureDisabled || stackCaptureDepth <= 0 {
+		return nil
+	}
+	pcs := make([]uintptr, stackCaptureDepth+8)
+	n := runtime.Callers(2, pcs)
+	if n == 0 {
+		return nil
+	}
+	frames := runtime.CallersFrames(pcs[:n])
+	ret := make([]runtime.Frame, 0, stackCaptureDepth)
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, pkgFuncPrefix) {
+			ret = append(ret, frame)
+			if len(ret) >= stackCaptureDepth {
+				break
+			}
+		}
+		if !more {
+			break
+		}
+	}
+	return ret
+}
+
+// StackTrace returns the stack frames that were captured when this Error
+// was created. It is nil if stack capturing was disabled (see
+// DisableStackCapture) at creation time.
+func (err Error) StackTrace() []runtime.Frame {
+	return err.Frames
+}
+
+// Format implements fmt.Formatter. %v and %s print the same output as
+// Error(). %+v additionally appends the stack trace that was captured for
+// this Error and for every wrapped Error, each as its own block of frame
+// lines starting on a new line.
+func (err Error) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		fmt.Fprint(s, err.Error())
+		if s.Flag('+') {
+			fmt.Fprint(s, err.formatFrames())
+		}
+	case 's':
+		fmt.Fprint(s, err.Error())
+	default:
+		fmt.Fprintf(s, "%%!%c(errors.Error)", verb)
+	}
+}
+
+// formatFrames renders the captured stack trace of this Error and of every
+// error it wraps (see Errs), recursively, as a sequence of "file:line
+// function" lines.
+func (err Error) formatFrames() string {
+	var b strings.Builder
+	err.writeFrames(&b)
+	return b.String()
+}
+
+func (err Error) writeFrames(b *strings.Builder) {
+	if len(err.Frames) > 0 {
+		b.WriteString("\n")
+		for _, f := range err.Frames {
+			fmt.Fprintf(b, "\t%s:%d %s\n", f.File, f.Line, f.Function)
+		}
+	}
+	for _, cause := range err.Errs {
+		if e, ok := cause.(Error); ok {
+			e.writeFrames(b)
+		}
+	}
+}